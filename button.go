@@ -8,8 +8,6 @@ import (
 	"strings"
 	"sync"
 	"time"
-
-	gpiocdev "github.com/warthog618/go-gpiocdev"
 )
 
 const (
@@ -33,38 +31,109 @@ const (
 // PinName accepts formats like "gpiochip0:23" or just "23" (defaults to gpiochip0).
 type ButtonConfig struct {
 	PinName      string         // Logical pin identifier: "gpiochipX:line" or "line"
-	Callback     ButtonCallback // Function called on button press
+	Callback     ButtonCallback // Deprecated: alias for OnPress, kept for compatibility.
 	DebounceTime time.Duration  // Minimum time between presses (default: 50ms)
+	DebounceMode DebounceMode   // How DebounceTime is enforced (default: Auto)
 	Pull         Pull           // Internal pull configuration (requires kernel support)
 	ActiveLow    bool           // Treat low level as logical 1 (typical for buttons to GND)
+
+	// OnPress and OnRelease fire on every debounced press/release transition
+	// (see DebounceMode).
+	OnPress   ButtonCallback
+	OnRelease ButtonCallback
+
+	// OnLongPress fires once, with how long the button has been held, when
+	// LongPressTime elapses while the button is still down. Disabled unless
+	// both OnLongPress and LongPressTime are set.
+	OnLongPress   func(pinName string, heldFor time.Duration)
+	LongPressTime time.Duration
+
+	// OnDoubleClick fires when a release follows the previous release within
+	// DoubleClickTime. Disabled unless both OnDoubleClick and DoubleClickTime
+	// are set.
+	OnDoubleClick   func(pinName string, interval time.Duration)
+	DoubleClickTime time.Duration
+
+	// OnHoldRepeat fires repeatedly while the button is held: first after
+	// HoldRepeatDelay, then every HoldRepeatPeriod. Disabled unless OnHoldRepeat
+	// and HoldRepeatPeriod are set.
+	OnHoldRepeat     ButtonCallback
+	HoldRepeatDelay  time.Duration
+	HoldRepeatPeriod time.Duration
+
+	// Events, if set, receives a copy of every event this button emits in
+	// addition to the On* callbacks above. Sends are non-blocking; a full
+	// channel drops the event and logs a warning.
+	Events chan<- Event
+
+	// Feedback, if set, drives a PWM-backed LED in response to this
+	// button's press/release/double-click events.
+	Feedback *FeedbackOutput
 }
 
 // ButtonManager manages multiple GPIO button inputs with interrupt-driven detection.
 type ButtonManager struct {
-	buttons map[string]*button
-	ctx     context.Context
-	cancel  context.CancelFunc
-	wg      sync.WaitGroup
-	mu      sync.Mutex
+	provider LineProvider
+	buttons  map[string]*button
+	groups   []*buttonGroup
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+
+	// observerMu guards observers and subscribers, dispatched to on every
+	// event any button emits (see dispatch).
+	observerMu  sync.Mutex
+	observers   []Observer
+	subscribers []chan Event
 }
 
-// button represents the internal state of a single button.
+// button represents the internal state of a single button, including the
+// press/release/hold state machine driven by both-edge GPIO events.
 type button struct {
-	line      *gpiocdev.Line
-	chip      string
-	offset    int
-	config    ButtonConfig
-	lastPress time.Time
-	mu        sync.Mutex
+	line    ProvidedLine
+	chip    string
+	offset  int
+	config  ButtonConfig
+	pinName string
+	manager *ButtonManager
+
+	mu              sync.Mutex
+	down            bool
+	lastPress       time.Time
+	lastRelease     time.Time
+	generation      uint64
+	longPressTimer  *time.Timer
+	holdRepeatTimer *time.Timer
+
+	// debounce is non-nil when software-integrator debouncing is active for
+	// this button (DebounceMode SoftwareIntegrator, or Auto without kernel
+	// debounce support). rawActive is the last edge-reported line level;
+	// stopSampler shuts down the periodic sampler goroutine that feeds it
+	// through the integrator.
+	debounce     *integrator
+	rawActive    bool
+	rawTimestamp time.Time
+	stopSampler  chan struct{}
 }
 
-// NewButtonManager creates and initializes a new ButtonManager.
+// NewButtonManager creates and initializes a new ButtonManager backed by the
+// Linux GPIO character device (gpiocdev). Use NewButtonManagerWithProvider to
+// pick a different backend, e.g. sysfsProvider or MockProvider.
 func NewButtonManager() (*ButtonManager, error) {
+	return NewButtonManagerWithProvider(NewGpiocdevProvider())
+}
+
+// NewButtonManagerWithProvider creates a ButtonManager driven by the given
+// LineProvider, so callers can target an alternate GPIO backend or
+// unit-test their callbacks against MockProvider without hardware.
+func NewButtonManagerWithProvider(p LineProvider) (*ButtonManager, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &ButtonManager{
-		buttons: make(map[string]*button),
-		ctx:     ctx,
-		cancel:  cancel,
+		provider: p,
+		buttons:  make(map[string]*button),
+		ctx:      ctx,
+		cancel:   cancel,
 	}, nil
 }
 
@@ -79,99 +148,55 @@ func (bm *ButtonManager) AddButton(config ButtonConfig) error {
 		config.DebounceTime = defaultDebounceTime
 	}
 
-	chip, offset, err := resolveChipLine(config.PinName)
+	chip, offset, err := resolveChipLine(bm.provider, config.PinName)
 	if err != nil {
 		return err
 	}
 
-	// Build request options and try fallbacks. Use event handler for rising edge (press).
-	base := []gpiocdev.LineReqOption{
-		gpiocdev.AsInput,
-		gpiocdev.WithConsumer("gpio-buttons-go"),
-		gpiocdev.WithRisingEdge,
-	}
-	if config.ActiveLow {
-		base = append(base, gpiocdev.AsActiveLow)
+	pinName := config.PinName
+	if pinName == "" {
+		pinName = fmt.Sprintf("%s:%d", chip, offset)
 	}
 
-	// Local debounce gate in case kernel debounce isn't available/enabled
-	var last time.Time
-	handler := func(evt gpiocdev.LineEvent) {
-		// evt.Type is RisingEdge for press due to WithRisingEdge
-		now := time.Now()
-		if config.DebounceTime > 0 && !last.IsZero() && now.Sub(last) <= config.DebounceTime/2 {
-			// Minimal guard against event bursts; kernel debounce should handle most cases
-			return
-		}
-		if config.Callback != nil {
-			pinName := config.PinName
-			if pinName == "" {
-				pinName = fmt.Sprintf("%s:%d", chip, offset)
-			}
-			config.Callback(pinName)
-		}
-		last = now
+	btn := &button{
+		chip:    chip,
+		offset:  offset,
+		config:  config,
+		pinName: pinName,
+		manager: bm,
 	}
 
-	// Construct option sets: full (debounce + bias), no bias, no debounce, base only
-	var combos [][]gpiocdev.LineReqOption
-	{
-		full := append([]gpiocdev.LineReqOption{}, base...)
-		full = append(full, gpiocdev.WithEventHandler(handler))
-		if config.DebounceTime > 0 {
-			full = append(full, gpiocdev.WithDebounce(config.DebounceTime))
-		}
-		if pOpt := pullOption(config.Pull); pOpt != nil {
-			full = append(full, pOpt)
-		}
-		combos = append(combos, full)
-
-		if pOpt := pullOption(config.Pull); pOpt != nil {
-			noBias := append([]gpiocdev.LineReqOption{}, base...)
-			noBias = append(noBias, gpiocdev.WithEventHandler(handler))
-			if config.DebounceTime > 0 {
-				noBias = append(noBias, gpiocdev.WithDebounce(config.DebounceTime))
-			}
-			combos = append(combos, noBias)
-		}
-		if config.DebounceTime > 0 {
-			noDeb := append([]gpiocdev.LineReqOption{}, base...)
-			noDeb = append(noDeb, gpiocdev.WithEventHandler(handler))
-			if pOpt := pullOption(config.Pull); pOpt != nil {
-				noDeb = append(noDeb, pOpt)
-			}
-			combos = append(combos, noDeb)
-		}
-		baseOnly := append([]gpiocdev.LineReqOption{}, base...)
-		baseOnly = append(baseOnly, gpiocdev.WithEventHandler(handler))
-		combos = append(combos, baseOnly)
+	line, err := bm.provider.RequestLine(LineRequest{
+		Chip:         chip,
+		Offset:       offset,
+		Consumer:     "gpio-buttons-go",
+		ActiveLow:    config.ActiveLow,
+		Pull:         config.Pull,
+		DebounceTime: config.DebounceTime,
+		EdgeHandler:  btn.handleEdge,
+	})
+	if err != nil {
+		return err
 	}
 
-	var line *gpiocdev.Line
-	var reqErr error
-	for _, opts := range combos {
-		line, reqErr = gpiocdev.RequestLine(chip, offset, opts...)
-		if reqErr == nil {
-			break
-		}
+	kernelDebounced := false
+	if r, ok := line.(interface{ KernelDebounced() bool }); ok {
+		kernelDebounced = r.KernelDebounced()
 	}
-	if reqErr != nil {
-		return fmt.Errorf("failed to request line %s:%d: %w", chip, offset, reqErr)
+	wantsIntegrator := config.DebounceMode == SoftwareIntegrator ||
+		(config.DebounceMode == Auto && !kernelDebounced)
+	if wantsIntegrator && config.DebounceTime > 0 {
+		btn.debounce = newIntegrator(config.DebounceTime)
+		btn.stopSampler = make(chan struct{})
+		bm.wg.Add(1)
+		go btn.runDebounceSampler(&bm.wg)
 	}
 
-	btn := &button{
-		line:   line,
-		chip:   chip,
-		offset: offset,
-		config: config,
-	}
-	key := config.PinName
-	if key == "" {
-		key = fmt.Sprintf("%s:%d", chip, offset)
-	}
-	bm.buttons[key] = btn
+	btn.line = line
+	bm.buttons[pinName] = btn
 
-	log.Printf("Added button on %s:%d (ActiveLow=%v, Debounce=%s, Pull=%v)", chip, offset, config.ActiveLow, config.DebounceTime, config.Pull)
+	log.Printf("Added button on %s:%d (ActiveLow=%v, Debounce=%s, DebounceMode=%v, KernelDebounced=%v, Pull=%v)",
+		chip, offset, config.ActiveLow, config.DebounceTime, config.DebounceMode, kernelDebounced, config.Pull)
 	return nil
 }
 
@@ -198,9 +223,34 @@ func (bm *ButtonManager) Stop() {
 				log.Printf("Error closing line %s:%d: %v", btn.chip, btn.offset, err)
 			}
 		}
+		// No further edges can arrive once the line is closed, so a button
+		// left down has no release coming to clear it. Reset its state and
+		// cancel its timers here, or a held button's long-press/hold-repeat
+		// timer (and any breathing feedback animation keyed off isHeldFor)
+		// would keep re-arming itself forever.
+		btn.stop()
+		if btn.stopSampler != nil {
+			close(btn.stopSampler)
+		}
+	}
+	for _, bg := range bm.groups {
+		if bg.lines != nil {
+			if err := bg.lines.Close(); err != nil {
+				log.Printf("Error closing button group: %v", err)
+			}
+		}
 	}
 	bm.mu.Unlock()
 
+	bm.wg.Wait()
+
+	bm.observerMu.Lock()
+	for _, ch := range bm.subscribers {
+		close(ch)
+	}
+	bm.subscribers = nil
+	bm.observerMu.Unlock()
+
 	log.Println("Stopped all button monitoring")
 }
 
@@ -211,39 +261,299 @@ func (bm *ButtonManager) GetButtonCount() int {
 	return len(bm.buttons)
 }
 
-// Helpers
-// Map Pull to appropriate request option for v0.9.1.
-func pullOption(p Pull) gpiocdev.LineReqOption {
-	switch p {
-	case PullUp:
-		return gpiocdev.WithPullUp
-	case PullDown:
-		return gpiocdev.WithPullDown
-	case PullDisabled:
-		return gpiocdev.WithBiasDisabled
-	default:
-		return nil // WithBiasAsIs is default
+// AddObserver registers o to receive every event emitted by any button this
+// manager controls, in addition to each button's own On* callbacks and
+// ButtonConfig.Events channel. Safe to call at any time, including after
+// buttons have been added.
+func (bm *ButtonManager) AddObserver(o Observer) {
+	bm.observerMu.Lock()
+	defer bm.observerMu.Unlock()
+	bm.observers = append(bm.observers, o)
+}
+
+// Events returns a channel receiving a copy of every event emitted by any
+// button this manager controls. Each call returns a distinct channel, so
+// multiple independent consumers (logging, replay, metrics) can subscribe
+// without stealing events from one another. The channel is buffered; a slow
+// consumer drops events rather than blocking button handling. It is closed
+// when Stop is called.
+func (bm *ButtonManager) Events() <-chan Event {
+	ch := make(chan Event, 32)
+	bm.observerMu.Lock()
+	bm.subscribers = append(bm.subscribers, ch)
+	bm.observerMu.Unlock()
+	return ch
+}
+
+// dispatch fans evt out to every registered Observer and Events() subscriber.
+func (bm *ButtonManager) dispatch(evt Event) {
+	bm.observerMu.Lock()
+	observers := bm.observers
+	subscribers := bm.subscribers
+	bm.observerMu.Unlock()
+
+	for _, o := range observers {
+		o.Observe(evt)
+	}
+	for _, ch := range subscribers {
+		select {
+		case ch <- evt:
+		default:
+			log.Printf("dropping %s event for %s: Events() subscriber channel full", evt.Kind, evt.PinName)
+		}
+	}
+}
+
+// isPressEdge reports whether the given edge type corresponds to a press,
+// accounting for ActiveLow wiring (buttons wired to ground present the
+// falling edge on press).
+func (b *button) isPressEdge(t EdgeType) bool {
+	if b.config.ActiveLow {
+		return t == FallingEdge
+	}
+	return t == RisingEdge
+}
+
+// handleEdge is the LineProvider edge callback. When software-integrator
+// debouncing is active it only records the raw level for the periodic
+// sampler to integrate; otherwise it forwards the raw edge directly,
+// trusting kernel debounce (or the minimal guard in handlePress).
+func (b *button) handleEdge(evt EdgeEvent) {
+	if b.debounce == nil {
+		if b.isPressEdge(evt.Type) {
+			b.handlePress(evt.Timestamp)
+		} else {
+			b.handleRelease(evt.Timestamp)
+		}
+		return
+	}
+
+	b.mu.Lock()
+	b.rawActive = b.isPressEdge(evt.Type)
+	b.rawTimestamp = evt.Timestamp
+	b.mu.Unlock()
+}
+
+// runDebounceSampler periodically feeds the last raw level through the
+// integrator debouncer until the button is stopped.
+func (b *button) runDebounceSampler(wg *sync.WaitGroup) {
+	defer wg.Done()
+	ticker := time.NewTicker(integratorSampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stopSampler:
+			return
+		case <-ticker.C:
+			b.sampleDebounce()
+		}
+	}
+}
+
+// sampleDebounce advances the integrator by one tick and, if the debounced
+// level just changed, forwards a press or release.
+func (b *button) sampleDebounce() {
+	b.mu.Lock()
+	raw := b.rawActive
+	ts := b.rawTimestamp
+	b.mu.Unlock()
+
+	active, changed := b.debounce.sample(raw)
+	if !changed {
+		return
+	}
+	if active {
+		b.handlePress(ts)
+	} else {
+		b.handleRelease(ts)
+	}
+}
+
+// handlePress runs on a debounced press, timestamped with ts (the wall time
+// the LineProvider captured when the edge was delivered, not the possibly
+// later time this handler happens to run): it fires OnPress/Callback, and
+// arms the long-press and hold-repeat timers.
+func (b *button) handlePress(ts time.Time) {
+	b.mu.Lock()
+	if b.down {
+		b.mu.Unlock()
+		return
+	}
+	if b.debounce == nil && b.config.DebounceTime > 0 && !b.lastPress.IsZero() && ts.Sub(b.lastPress) <= b.config.DebounceTime/2 {
+		b.mu.Unlock()
+		return
+	}
+	b.down = true
+	b.lastPress = ts
+	b.generation++
+	gen := b.generation
+	b.mu.Unlock()
+
+	b.emit(EventPress, ts)
+	if b.config.Callback != nil {
+		b.config.Callback(b.pinName)
+	}
+	if b.config.OnPress != nil {
+		b.config.OnPress(b.pinName)
+	}
+
+	if b.config.OnLongPress != nil && b.config.LongPressTime > 0 {
+		b.mu.Lock()
+		b.longPressTimer = time.AfterFunc(b.config.LongPressTime, func() { b.fireLongPress(gen) })
+		b.mu.Unlock()
+	}
+	if b.config.OnHoldRepeat != nil && b.config.HoldRepeatPeriod > 0 {
+		b.mu.Lock()
+		b.holdRepeatTimer = time.AfterFunc(b.config.HoldRepeatDelay, func() { b.fireHoldRepeat(gen) })
+		b.mu.Unlock()
+	}
+	b.config.Feedback.onPress(b, gen)
+}
+
+// isHeldFor reports whether the button is still down from press generation
+// gen, letting a feedback animation know when to stop.
+func (b *button) isHeldFor(gen uint64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.down && b.generation == gen
+}
+
+// stop cancels any armed long-press/hold-repeat timers and clears the press
+// state. Called from ButtonManager.Stop() after the line is closed, since at
+// that point no release edge will ever arrive to do it naturally: without
+// this, a button that was held down when the manager stopped would have
+// fireHoldRepeat (and any feedback animation keyed off isHeldFor) keep
+// re-arming itself forever.
+func (b *button) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.down = false
+	if b.longPressTimer != nil {
+		b.longPressTimer.Stop()
+		b.longPressTimer = nil
 	}
+	if b.holdRepeatTimer != nil {
+		b.holdRepeatTimer.Stop()
+		b.holdRepeatTimer = nil
+	}
+}
+
+// handleRelease runs on the release edge, timestamped with ts (the wall time
+// the LineProvider captured when the edge was delivered, not the possibly
+// later time this handler happens to run): it cancels the hold timers, fires
+// OnRelease, and checks whether this release completes a double-click.
+func (b *button) handleRelease(ts time.Time) {
+	b.mu.Lock()
+	if !b.down {
+		b.mu.Unlock()
+		return
+	}
+	b.down = false
+	if b.longPressTimer != nil {
+		b.longPressTimer.Stop()
+		b.longPressTimer = nil
+	}
+	if b.holdRepeatTimer != nil {
+		b.holdRepeatTimer.Stop()
+		b.holdRepeatTimer = nil
+	}
+	prevRelease := b.lastRelease
+	b.lastRelease = ts
+	b.mu.Unlock()
+
+	b.emit(EventRelease, ts)
+	if b.config.OnRelease != nil {
+		b.config.OnRelease(b.pinName)
+	}
+
+	if b.config.OnDoubleClick == nil || b.config.DoubleClickTime <= 0 || prevRelease.IsZero() {
+		return
+	}
+	interval := ts.Sub(prevRelease)
+	if interval > b.config.DoubleClickTime {
+		return
+	}
+	// Consume the pair so a third click starts a fresh count.
+	b.mu.Lock()
+	b.lastRelease = time.Time{}
+	b.mu.Unlock()
+
+	b.emit(EventDoubleClick, ts)
+	b.config.OnDoubleClick(b.pinName, interval)
+	b.config.Feedback.onDoubleClick(b)
+}
+
+// fireLongPress delivers OnLongPress once, provided the button is still down
+// from the same press (gen) that armed the timer.
+func (b *button) fireLongPress(gen uint64) {
+	b.mu.Lock()
+	if b.generation != gen || !b.down {
+		b.mu.Unlock()
+		return
+	}
+	b.mu.Unlock()
+
+	now := time.Now()
+	b.emit(EventLongPress, now)
+	b.config.OnLongPress(b.pinName, b.config.LongPressTime)
+}
+
+// fireHoldRepeat delivers OnHoldRepeat and reschedules itself at
+// HoldRepeatPeriod while the button remains held from the same press (gen).
+func (b *button) fireHoldRepeat(gen uint64) {
+	b.mu.Lock()
+	if b.generation != gen || !b.down {
+		b.mu.Unlock()
+		return
+	}
+	b.holdRepeatTimer = time.AfterFunc(b.config.HoldRepeatPeriod, func() { b.fireHoldRepeat(gen) })
+	b.mu.Unlock()
+
+	b.emit(EventHoldRepeat, time.Now())
+	b.config.OnHoldRepeat(b.pinName)
 }
 
-// resolveChipLine parses PinName into chip and offset.
-// Accepts "gpiochipX:line" or just "line" (defaults to gpiochip0).
-func resolveChipLine(pinName string) (string, int, error) {
+// emit sends ev on the configured Events channel, if any, without blocking,
+// and fans it out to the manager's observers and Events() subscribers.
+func (b *button) emit(kind EventKind, ts time.Time) {
+	evt := Event{PinName: b.pinName, Kind: kind, Timestamp: ts}
+	if b.config.Events != nil {
+		select {
+		case b.config.Events <- evt:
+		default:
+			log.Printf("dropping %s event for %s: Events channel full", kind, b.pinName)
+		}
+	}
+	if b.manager != nil {
+		b.manager.dispatch(evt)
+	}
+}
+
+// resolveChipLine parses PinName into chip and offset. It accepts three
+// forms: "gpiochipX:line", a bare "line" (defaults to gpiochip0), or a
+// symbolic kernel line name such as "BUTTON_A" or "gpio-keys:power", resolved
+// by scanning provider.Chips() for a matching LineInfo.Name. Resolving
+// through the caller's LineProvider (rather than gpiocdev directly) means a
+// ButtonManager backed by MockProvider or sysfsProvider never reaches out to
+// real hardware to resolve a symbolic name. Symbolic names also let a
+// ButtonConfig stay portable across boards where numeric offsets differ (Pi
+// 4 vs Pi 5 vs BeagleBone).
+func resolveChipLine(provider LineProvider, pinName string) (string, int, error) {
 	p := strings.TrimSpace(pinName)
 	if p == "" {
-		return "", 0, fmt.Errorf("PinName is required; format 'gpiochipX:line' or 'line'")
+		return "", 0, fmt.Errorf("PinName is required; format 'gpiochipX:line', 'line', or a symbolic line name")
 	}
 	if strings.Contains(p, ":") {
 		parts := strings.SplitN(p, ":", 2)
-		off, err := strconv.Atoi(parts[1])
-		if err != nil {
-			return "", 0, fmt.Errorf("invalid line offset %q: %w", parts[1], err)
+		if off, err := strconv.Atoi(parts[1]); err == nil {
+			return parts[0], off, nil
 		}
-		return parts[0], off, nil
+		// Not "chip:offset" - treat the whole string as a symbolic name
+		// (e.g. "gpio-keys:power").
+		return resolveLineName(provider, p)
 	}
-	off, err := strconv.Atoi(p)
-	if err != nil {
-		return "", 0, fmt.Errorf("invalid PinName %q", p)
+	if off, err := strconv.Atoi(p); err == nil {
+		return "gpiochip0", off, nil
 	}
-	return "gpiochip0", off, nil
+	return resolveLineName(provider, p)
 }
@@ -0,0 +1,220 @@
+package gpiobuttons
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAddButtonWithMockProvider(t *testing.T) {
+	provider := NewMockProvider()
+	bm, err := NewButtonManagerWithProvider(provider)
+	if err != nil {
+		t.Fatalf("NewButtonManagerWithProvider: %v", err)
+	}
+
+	presses := 0
+	err = bm.AddButton(ButtonConfig{
+		PinName:      "mock0:5",
+		ActiveLow:    true,
+		DebounceMode: KernelOnly, // exercise raw edge handling without the integrator
+		OnPress:      func(pinName string) { presses++ },
+	})
+	if err != nil {
+		t.Fatalf("AddButton: %v", err)
+	}
+
+	provider.Inject("mock0", 5, EdgeEvent{Type: FallingEdge, Timestamp: time.Now()})
+	provider.Inject("mock0", 5, EdgeEvent{Type: RisingEdge, Timestamp: time.Now()})
+
+	if presses != 1 {
+		t.Fatalf("expected 1 press, got %d", presses)
+	}
+
+	bm.Stop()
+}
+
+func TestAddGroupFiresCallbackOnChord(t *testing.T) {
+	provider := NewMockProvider()
+	bm, err := NewButtonManagerWithProvider(provider)
+	if err != nil {
+		t.Fatalf("NewButtonManagerWithProvider: %v", err)
+	}
+
+	var held []string
+	fired := 0
+	err = bm.AddGroup(GroupConfig{
+		Pins:        []string{"mock0:1", "mock0:2"},
+		ChordWindow: 50 * time.Millisecond,
+		ActiveLow:   true,
+		Callback: func(pins []string) {
+			fired++
+			held = pins
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	// Holding only one line should not fire the chord.
+	provider.Inject("mock0", 1, EdgeEvent{Type: FallingEdge, Timestamp: time.Now()})
+	if fired != 0 {
+		t.Fatalf("expected no chord with only one pin held, got %d", fired)
+	}
+
+	// The second line completes the chord within the window.
+	provider.Inject("mock0", 2, EdgeEvent{Type: FallingEdge, Timestamp: time.Now()})
+	if fired != 1 {
+		t.Fatalf("expected chord to fire once both pins are held, got %d", fired)
+	}
+	if len(held) != 2 || held[0] != "mock0:1" || held[1] != "mock0:2" {
+		t.Fatalf("expected held pins [mock0:1 mock0:2], got %v", held)
+	}
+
+	bm.Stop()
+}
+
+func TestObserverAndEventsReceivePressAndRelease(t *testing.T) {
+	provider := NewMockProvider()
+	bm, err := NewButtonManagerWithProvider(provider)
+	if err != nil {
+		t.Fatalf("NewButtonManagerWithProvider: %v", err)
+	}
+
+	metrics := NewMetricsObserver()
+	bm.AddObserver(metrics)
+	events := bm.Events()
+
+	err = bm.AddButton(ButtonConfig{
+		PinName:      "mock0:7",
+		ActiveLow:    true,
+		DebounceMode: KernelOnly,
+	})
+	if err != nil {
+		t.Fatalf("AddButton: %v", err)
+	}
+
+	provider.Inject("mock0", 7, EdgeEvent{Type: FallingEdge, Timestamp: time.Now()})
+	provider.Inject("mock0", 7, EdgeEvent{Type: RisingEdge, Timestamp: time.Now()})
+
+	if kind := (<-events).Kind; kind != EventPress {
+		t.Fatalf("expected EventPress, got %v", kind)
+	}
+	if kind := (<-events).Kind; kind != EventRelease {
+		t.Fatalf("expected EventRelease, got %v", kind)
+	}
+
+	metrics.mu.Lock()
+	presses := metrics.presses["mock0:7"]
+	held := metrics.held["mock0:7"]
+	metrics.mu.Unlock()
+	if presses != 1 {
+		t.Fatalf("expected MetricsObserver to count 1 press, got %d", presses)
+	}
+	if held {
+		t.Fatalf("expected button to be reported released after the release event")
+	}
+
+	bm.Stop()
+	if _, ok := <-events; ok {
+		t.Fatalf("expected Events() channel to be closed after Stop")
+	}
+}
+
+func TestStopCancelsHoldRepeatForAHeldButton(t *testing.T) {
+	provider := NewMockProvider()
+	bm, err := NewButtonManagerWithProvider(provider)
+	if err != nil {
+		t.Fatalf("NewButtonManagerWithProvider: %v", err)
+	}
+
+	var repeats int32
+	err = bm.AddButton(ButtonConfig{
+		PinName:          "mock0:8",
+		ActiveLow:        true,
+		DebounceMode:     KernelOnly,
+		OnHoldRepeat:     func(pinName string) { atomic.AddInt32(&repeats, 1) },
+		HoldRepeatDelay:  time.Millisecond,
+		HoldRepeatPeriod: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("AddButton: %v", err)
+	}
+
+	// Hold the button down and leave it held (no release edge) when Stop runs.
+	provider.Inject("mock0", 8, EdgeEvent{Type: FallingEdge, Timestamp: time.Now()})
+	time.Sleep(5 * time.Millisecond)
+
+	bm.Stop()
+	afterStop := atomic.LoadInt32(&repeats)
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(&repeats); got != afterStop {
+		t.Fatalf("expected OnHoldRepeat to stop firing once Stop returns, got %d more firings", got-afterStop)
+	}
+}
+
+func TestResolveChipLineSymbolicNameUsesProvider(t *testing.T) {
+	provider := NewMockProvider()
+
+	// "gpio-keys:power"-style symbolic name: contains ':' but the suffix
+	// isn't numeric, so resolveChipLine must resolve it by scanning the
+	// given provider rather than falling back to real gpiocdev hardware.
+	// MockProvider never synthesizes a matching name, so this should return
+	// a clean "not found" error quickly instead of hanging or touching
+	// /dev/gpiochipN.
+	if _, _, err := resolveChipLine(provider, "gpio-keys:power"); err == nil {
+		t.Fatalf("expected an error resolving a symbolic name no provider line has")
+	}
+}
+
+func TestSoftwareIntegratorDebouncesBounce(t *testing.T) {
+	provider := NewMockProvider()
+	bm, err := NewButtonManagerWithProvider(provider)
+	if err != nil {
+		t.Fatalf("NewButtonManagerWithProvider: %v", err)
+	}
+
+	// presses/releases are written from the integrator's sampler goroutine
+	// (runDebounceSampler -> handlePress/handleRelease) and read from this
+	// goroutine, so they must be accessed atomically rather than as bare ints.
+	var presses, releases int32
+	err = bm.AddButton(ButtonConfig{
+		PinName:      "mock0:6",
+		ActiveLow:    true,
+		DebounceTime: 5 * time.Millisecond,
+		DebounceMode: SoftwareIntegrator,
+		OnPress:      func(pinName string) { atomic.AddInt32(&presses, 1) },
+		OnRelease:    func(pinName string) { atomic.AddInt32(&releases, 1) },
+	})
+	if err != nil {
+		t.Fatalf("AddButton: %v", err)
+	}
+
+	// A noisy mechanical press: raw level bounces for under a debounce
+	// window, so the integrator should not register a press.
+	for i := 0; i < 3; i++ {
+		provider.Inject("mock0", 6, EdgeEvent{Type: FallingEdge, Timestamp: time.Now()})
+		time.Sleep(time.Millisecond)
+		provider.Inject("mock0", 6, EdgeEvent{Type: RisingEdge, Timestamp: time.Now()})
+		time.Sleep(time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&presses); got != 0 {
+		t.Fatalf("expected the bounce to be filtered out with no press, got %d", got)
+	}
+
+	// A clean press held well past the debounce window is registered, and so
+	// is the release that follows it.
+	provider.Inject("mock0", 6, EdgeEvent{Type: FallingEdge, Timestamp: time.Now()})
+	time.Sleep(20 * time.Millisecond)
+	if got := atomic.LoadInt32(&presses); got != 1 {
+		t.Fatalf("expected a debounced press, got %d", got)
+	}
+
+	provider.Inject("mock0", 6, EdgeEvent{Type: RisingEdge, Timestamp: time.Now()})
+	time.Sleep(20 * time.Millisecond)
+	if got := atomic.LoadInt32(&releases); got != 1 {
+		t.Fatalf("expected a debounced release, got %d", got)
+	}
+
+	bm.Stop()
+}
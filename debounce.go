@@ -0,0 +1,82 @@
+package gpiobuttons
+
+import (
+	"sync"
+	"time"
+)
+
+// DebounceMode selects how a button's raw edges are debounced.
+type DebounceMode int
+
+const (
+	// Auto prefers kernel debounce (gpiocdev.WithDebounce) and falls back to
+	// the software integrator when the kernel/driver doesn't honor it. This
+	// is the default.
+	Auto DebounceMode = iota
+	// KernelOnly trusts the kernel/driver to debounce and applies no
+	// software filtering, beyond the minimal guard in handlePress.
+	KernelOnly
+	// SoftwareIntegrator always runs the integrator debouncer, regardless of
+	// whether the kernel accepted WithDebounce.
+	SoftwareIntegrator
+)
+
+// String renders the debounce mode for logging.
+func (m DebounceMode) String() string {
+	switch m {
+	case KernelOnly:
+		return "KernelOnly"
+	case SoftwareIntegrator:
+		return "SoftwareIntegrator"
+	default:
+		return "Auto"
+	}
+}
+
+// integratorSampleInterval is the sampling period of the software debouncer's
+// periodic sampler.
+const integratorSampleInterval = 1 * time.Millisecond
+
+// integrator is a Schmitt-trigger-style debouncer: an up/down counter clamped
+// to [0, max] that increments while the raw input is active and decrements
+// while inactive. The debounced output flips to active when the counter
+// reaches max, and to inactive when it reaches 0.
+type integrator struct {
+	mu      sync.Mutex
+	counter int
+	max     int
+	active  bool
+}
+
+// newIntegrator builds an integrator whose max count corresponds to
+// debounceTime at the package's sample interval.
+func newIntegrator(debounceTime time.Duration) *integrator {
+	max := int(debounceTime / integratorSampleInterval)
+	if max < 1 {
+		max = 1
+	}
+	return &integrator{max: max}
+}
+
+// sample advances the integrator by one tick at the given raw level and
+// reports the debounced level plus whether it just changed.
+func (d *integrator) sample(level bool) (active, changed bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if level {
+		if d.counter < d.max {
+			d.counter++
+		}
+	} else if d.counter > 0 {
+		d.counter--
+	}
+
+	prev := d.active
+	if d.counter >= d.max {
+		d.active = true
+	} else if d.counter <= 0 {
+		d.active = false
+	}
+	return d.active, d.active != prev
+}
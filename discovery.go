@@ -0,0 +1,91 @@
+package gpiobuttons
+
+import (
+	"fmt"
+
+	gpiocdev "github.com/warthog618/go-gpiocdev"
+)
+
+// LineInfo describes a single GPIO line discovered on the system, along with
+// whether it accepted a probing pull-up request.
+type LineInfo struct {
+	Chip           string
+	Offset         int
+	Name           string
+	Consumer       string
+	Direction      string
+	SupportsPullUp bool
+}
+
+// DiscoverLines enumerates every line on every available GPIO chip. For each
+// line it probes pull-up support with a throwaway RequestLine, so the result
+// can be used to pick a button-capable pin without wiring up external
+// resistors. Lines already in use (e.g. claimed by gpio-keys) report
+// SupportsPullUp=false rather than failing the whole scan.
+func DiscoverLines() ([]LineInfo, error) {
+	chipNames := gpiocdev.Chips()
+	if len(chipNames) == 0 {
+		return nil, fmt.Errorf("no gpiochips found")
+	}
+
+	var lines []LineInfo
+	for _, cname := range chipNames {
+		c, err := gpiocdev.NewChip(cname)
+		if err != nil {
+			continue
+		}
+		n := c.Lines()
+		for off := 0; off < n; off++ {
+			li, err := c.LineInfo(off)
+			if err != nil {
+				continue
+			}
+			direction := "input"
+			if li.Config.Direction == gpiocdev.LineDirectionOutput {
+				direction = "output"
+			}
+			info := LineInfo{
+				Chip:      cname,
+				Offset:    off,
+				Name:      li.Name,
+				Consumer:  li.Consumer,
+				Direction: direction,
+			}
+			if l, err := gpiocdev.RequestLine(cname, off, gpiocdev.AsInput, gpiocdev.WithPullUp, gpiocdev.WithConsumer("gpio-buttons-go-discover")); err == nil {
+				info.SupportsPullUp = true
+				_ = l.Close()
+			}
+			lines = append(lines, info)
+		}
+		_ = c.Close()
+	}
+	return lines, nil
+}
+
+// maxLineScan bounds resolveLineName's per-chip offset scan. gpiocdevProvider
+// naturally stops early: LineInfo errors once offset exceeds the chip's real
+// line count. MockProvider and sysfsProvider synthesize a LineInfo for any
+// offset and never error, so without a cap the scan would never terminate.
+const maxLineScan = 1024
+
+// resolveLineName resolves a symbolic line name, e.g. "BUTTON_A" or
+// "gpio-keys:power", to a chip and offset by scanning p.Chips() for a
+// LineInfo.Name match. Resolving through the LineProvider, rather than
+// gpiocdev directly, lets ButtonConfig.PinName stay stable across boards
+// where numeric offsets differ but the kernel line label does not, and lets
+// callers using MockProvider (or sysfsProvider) resolve names without ever
+// touching real hardware.
+func resolveLineName(p LineProvider, name string) (string, int, error) {
+	for _, cname := range p.Chips() {
+		for offset := 0; offset < maxLineScan; offset++ {
+			li, err := p.LineInfo(cname, offset)
+			if err != nil {
+				break
+			}
+			if li.Name == name {
+				return cname, offset, nil
+			}
+		}
+	}
+	return "", 0, fmt.Errorf("no line named %q found on any chip", name)
+}
@@ -0,0 +1,41 @@
+package gpiobuttons
+
+import "time"
+
+// EventKind identifies the semantic meaning of an Event.
+type EventKind int
+
+const (
+	EventPress EventKind = iota
+	EventRelease
+	EventLongPress
+	EventDoubleClick
+	EventHoldRepeat
+)
+
+// String renders the event kind for logging.
+func (k EventKind) String() string {
+	switch k {
+	case EventPress:
+		return "press"
+	case EventRelease:
+		return "release"
+	case EventLongPress:
+		return "long-press"
+	case EventDoubleClick:
+		return "double-click"
+	case EventHoldRepeat:
+		return "hold-repeat"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a single semantic button event. It is delivered on a
+// button's ButtonConfig.Events channel, when configured, in addition to the
+// matching On* callback.
+type Event struct {
+	PinName   string
+	Kind      EventKind
+	Timestamp time.Time
+}
@@ -0,0 +1,107 @@
+package gpiobuttons
+
+import (
+	"time"
+
+	"github.com/piotrpaczula/gpio-buttons-go/pwm"
+)
+
+const (
+	defaultBreathePeriod = 1 * time.Second
+	defaultBlinkDuration = 100 * time.Millisecond
+	feedbackStepInterval = 20 * time.Millisecond
+)
+
+// FeedbackOutput drives a PWM-backed LED in response to a button's events, so
+// a press gets visual acknowledgement without the caller writing their own
+// PWM plumbing.
+type FeedbackOutput struct {
+	Output *pwm.PWMOutput
+
+	// BreatheWhileHeld ramps duty cycle up and down smoothly for as long as
+	// the button is held.
+	BreatheWhileHeld bool
+	BreathePeriod    time.Duration // default: 1s
+
+	// BlinkOnDoubleClick pulses the LED once, briefly, when OnDoubleClick fires.
+	BlinkOnDoubleClick bool
+	BlinkDuration      time.Duration // default: 100ms
+}
+
+// onPress starts the breathing animation, if configured, for the given press
+// generation. The animation stops itself once gen is no longer current, and
+// is tracked by b.manager's WaitGroup so ButtonManager.Stop() waits for it to
+// exit rather than leaving it running against lines that are about to close.
+func (f *FeedbackOutput) onPress(b *button, gen uint64) {
+	if f == nil || f.Output == nil || !f.BreatheWhileHeld {
+		return
+	}
+	period := f.BreathePeriod
+	if period <= 0 {
+		period = defaultBreathePeriod
+	}
+	if b.manager != nil {
+		b.manager.wg.Add(1)
+	}
+	go func() {
+		if b.manager != nil {
+			defer b.manager.wg.Done()
+		}
+		f.breathe(b, gen, period)
+	}()
+}
+
+// onDoubleClick fires a single brief pulse, if configured, tracked by
+// b.manager's WaitGroup for the same reason as onPress above.
+func (f *FeedbackOutput) onDoubleClick(b *button) {
+	if f == nil || f.Output == nil || !f.BlinkOnDoubleClick {
+		return
+	}
+	duration := f.BlinkDuration
+	if duration <= 0 {
+		duration = defaultBlinkDuration
+	}
+	if b.manager != nil {
+		b.manager.wg.Add(1)
+	}
+	go func() {
+		if b.manager != nil {
+			defer b.manager.wg.Done()
+		}
+		_ = f.Output.SetDutyCycle(f.Output.Period())
+		_ = f.Output.Start()
+		time.Sleep(duration)
+		_ = f.Output.Stop()
+	}()
+}
+
+// breathe ramps duty cycle up and down at feedbackStepInterval while b
+// remains held from press generation gen.
+func (f *FeedbackOutput) breathe(b *button, gen uint64, period time.Duration) {
+	steps := int(period / (2 * feedbackStepInterval))
+	if steps < 1 {
+		steps = 1
+	}
+	_ = f.Output.Start()
+	defer func() { _ = f.Output.Stop() }()
+
+	full := f.Output.Period()
+	for {
+		for i := 0; i <= steps; i++ {
+			if !b.isHeldFor(gen) {
+				return
+			}
+			fraction := float64(i) / float64(steps)
+			_ = f.Output.SetDutyCycle(time.Duration(fraction * float64(full)))
+			time.Sleep(feedbackStepInterval)
+		}
+		for i := steps; i >= 0; i-- {
+			if !b.isHeldFor(gen) {
+				return
+			}
+			fraction := float64(i) / float64(steps)
+			_ = f.Output.SetDutyCycle(time.Duration(fraction * float64(full)))
+			time.Sleep(feedbackStepInterval)
+		}
+	}
+}
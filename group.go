@@ -0,0 +1,196 @@
+package gpiobuttons
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const defaultChordWindow = 200 * time.Millisecond
+
+// GroupConfig configures a ButtonGroup: a set of lines on the same chip
+// requested together (atomically when the provider implements
+// GroupLineProvider, one line at a time otherwise), optionally firing
+// Callback only when a Chord subset of Pins is simultaneously held within
+// ChordWindow (e.g. a Ctrl+Alt+Del-style combo, or "hold A while pressing
+// B"). PinName formats match ButtonConfig.PinName.
+type GroupConfig struct {
+	Pins        []string            // Pins to request together; must resolve to one chip
+	Chord       []string            // Subset of Pins that must be held together; defaults to all of Pins
+	ChordWindow time.Duration       // Max spread between the chord members' presses (default: 200ms)
+	ActiveLow   bool                // Treat low level as logical 1 (typical for buttons to GND)
+	Pull        Pull                // Internal pull configuration (requires kernel support)
+	Callback    func(pins []string) // Invoked on each press in the group while the chord is held, with the chord pins
+}
+
+// buttonGroup is the runtime state for one GroupConfig: which lines are
+// currently held, and since when.
+type buttonGroup struct {
+	config      GroupConfig
+	chord       []string
+	pinByOffset map[int]string
+	lines       ProvidedLineGroup
+
+	mu       sync.Mutex
+	activeAt map[string]time.Time
+}
+
+// AddGroup registers a ButtonGroup. Must be called before Start().
+func (bm *ButtonManager) AddGroup(config GroupConfig) error {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if len(config.Pins) == 0 {
+		return fmt.Errorf("GroupConfig.Pins must not be empty")
+	}
+	if config.ChordWindow == 0 {
+		config.ChordWindow = defaultChordWindow
+	}
+	chord := config.Chord
+	if len(chord) == 0 {
+		chord = config.Pins
+	}
+
+	var chip string
+	offsets := make([]int, len(config.Pins))
+	pinByOffset := make(map[int]string, len(config.Pins))
+	for i, pin := range config.Pins {
+		c, off, err := resolveChipLine(bm.provider, pin)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			chip = c
+		} else if c != chip {
+			return fmt.Errorf("GroupConfig.Pins must share one chip: %q is on %q, %q is on %q", config.Pins[0], chip, pin, c)
+		}
+		offsets[i] = off
+		pinByOffset[off] = pin
+	}
+
+	bg := &buttonGroup{
+		config:      config,
+		chord:       chord,
+		pinByOffset: pinByOffset,
+		activeAt:    make(map[string]time.Time),
+	}
+
+	lines, err := bm.requestGroupLines(chip, offsets, config, bg)
+	if err != nil {
+		return err
+	}
+	bg.lines = lines
+	bm.groups = append(bm.groups, bg)
+
+	log.Printf("Added button group on %s%v (Chord=%v, ChordWindow=%s)", chip, offsets, chord, config.ChordWindow)
+	return nil
+}
+
+// requestGroupLines requests offsets atomically via GroupLineProvider when
+// the manager's provider supports it, and falls back to per-line requests
+// otherwise (still functional, just not atomic).
+func (bm *ButtonManager) requestGroupLines(chip string, offsets []int, config GroupConfig, bg *buttonGroup) (ProvidedLineGroup, error) {
+	handler := func(offset int, evt EdgeEvent) {
+		bg.handleEdge(bg.pinByOffset[offset], evt)
+	}
+
+	if gp, ok := bm.provider.(GroupLineProvider); ok {
+		return gp.RequestLines(LineGroupRequest{
+			Chip:        chip,
+			Offsets:     offsets,
+			Consumer:    "gpio-buttons-go-group",
+			ActiveLow:   config.ActiveLow,
+			Pull:        config.Pull,
+			EdgeHandler: handler,
+		})
+	}
+
+	lines := make(multiLine, 0, len(offsets))
+	for _, off := range offsets {
+		off := off
+		line, err := bm.provider.RequestLine(LineRequest{
+			Chip:      chip,
+			Offset:    off,
+			Consumer:  "gpio-buttons-go-group",
+			ActiveLow: config.ActiveLow,
+			Pull:      config.Pull,
+			EdgeHandler: func(evt EdgeEvent) {
+				handler(off, evt)
+			},
+		})
+		if err != nil {
+			_ = lines.Close()
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// multiLine closes a set of independently-requested lines together; used as
+// the ProvidedLineGroup fallback for providers without native RequestLines.
+type multiLine []ProvidedLine
+
+func (m multiLine) Close() error {
+	var firstErr error
+	for _, l := range m {
+		if err := l.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// isPressEdge mirrors button.isPressEdge for the group's ActiveLow setting.
+func (bg *buttonGroup) isPressEdge(t EdgeType) bool {
+	if bg.config.ActiveLow {
+		return t == FallingEdge
+	}
+	return t == RisingEdge
+}
+
+// handleEdge updates which pins are currently held and fires Callback on any
+// press edge of the group while every chord pin is held with their presses
+// within ChordWindow of one another.
+func (bg *buttonGroup) handleEdge(pinName string, evt EdgeEvent) {
+	active := bg.isPressEdge(evt.Type)
+
+	bg.mu.Lock()
+	if active {
+		bg.activeAt[pinName] = evt.Timestamp
+	} else {
+		delete(bg.activeAt, pinName)
+	}
+	satisfied, held := bg.chordHeldLocked()
+	bg.mu.Unlock()
+
+	if active && satisfied && bg.config.Callback != nil {
+		bg.config.Callback(held)
+	}
+}
+
+// chordHeldLocked reports whether every pin in the chord is currently active
+// and their presses fell within ChordWindow of one another. Callers must
+// hold bg.mu.
+func (bg *buttonGroup) chordHeldLocked() (bool, []string) {
+	var earliest, latest time.Time
+	for _, pin := range bg.chord {
+		at, ok := bg.activeAt[pin]
+		if !ok {
+			return false, nil
+		}
+		if earliest.IsZero() || at.Before(earliest) {
+			earliest = at
+		}
+		if at.After(latest) {
+			latest = at
+		}
+	}
+	if latest.Sub(earliest) > bg.config.ChordWindow {
+		return false, nil
+	}
+	held := make([]string, len(bg.chord))
+	copy(held, bg.chord)
+	return true, held
+}
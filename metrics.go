@@ -0,0 +1,144 @@
+package gpiobuttons
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Observer receives every event emitted by any button managed by a
+// ButtonManager, in addition to each button's own On* callbacks and
+// ButtonConfig.Events channel. Register one with ButtonManager.AddObserver.
+type Observer interface {
+	Observe(evt Event)
+}
+
+// histogramBuckets are the upper bounds (seconds) used by MetricsObserver's
+// histograms.
+var histogramBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// MetricsObserver is a built-in Observer that exports Prometheus text-format
+// counters, histograms, and gauges of button activity. It implements
+// http.Handler, so it can be registered directly with an http.ServeMux.
+type MetricsObserver struct {
+	mu sync.Mutex
+
+	presses    map[string]uint64
+	held       map[string]bool
+	lastPress  map[string]time.Time
+	pressedAt  map[string]time.Time
+	interPress map[string]*histogram // seconds between consecutive presses
+	durations  map[string]*histogram // seconds a press was held for
+}
+
+// NewMetricsObserver returns an empty MetricsObserver ready to register with
+// ButtonManager.AddObserver and to serve via http.Handle.
+func NewMetricsObserver() *MetricsObserver {
+	return &MetricsObserver{
+		presses:    make(map[string]uint64),
+		held:       make(map[string]bool),
+		lastPress:  make(map[string]time.Time),
+		pressedAt:  make(map[string]time.Time),
+		interPress: make(map[string]*histogram),
+		durations:  make(map[string]*histogram),
+	}
+}
+
+// Observe implements Observer.
+func (m *MetricsObserver) Observe(evt Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch evt.Kind {
+	case EventPress:
+		m.presses[evt.PinName]++
+		if last, ok := m.lastPress[evt.PinName]; ok {
+			m.histogramFor(m.interPress, evt.PinName).observe(evt.Timestamp.Sub(last).Seconds())
+		}
+		m.lastPress[evt.PinName] = evt.Timestamp
+		m.pressedAt[evt.PinName] = evt.Timestamp
+		m.held[evt.PinName] = true
+	case EventRelease:
+		if start, ok := m.pressedAt[evt.PinName]; ok {
+			m.histogramFor(m.durations, evt.PinName).observe(evt.Timestamp.Sub(start).Seconds())
+		}
+		m.held[evt.PinName] = false
+	}
+}
+
+// histogramFor returns pin's histogram in byPin, creating it on first use.
+func (m *MetricsObserver) histogramFor(byPin map[string]*histogram, pin string) *histogram {
+	h, ok := byPin[pin]
+	if !ok {
+		h = newHistogram()
+		byPin[pin] = h
+	}
+	return h
+}
+
+// ServeHTTP writes the current metrics in Prometheus text exposition format.
+func (m *MetricsObserver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintln(w, "# HELP gpio_button_presses_total Total number of button presses.")
+	fmt.Fprintln(w, "# TYPE gpio_button_presses_total counter")
+	for pin, n := range m.presses {
+		fmt.Fprintf(w, "gpio_button_presses_total{pin=%q} %d\n", pin, n)
+	}
+
+	fmt.Fprintln(w, "# HELP gpio_button_held Whether a button is currently held down.")
+	fmt.Fprintln(w, "# TYPE gpio_button_held gauge")
+	for pin, held := range m.held {
+		v := 0
+		if held {
+			v = 1
+		}
+		fmt.Fprintf(w, "gpio_button_held{pin=%q} %d\n", pin, v)
+	}
+
+	writeHistogram(w, "gpio_button_inter_press_seconds", "Seconds between consecutive presses of a button.", m.interPress)
+	writeHistogram(w, "gpio_button_press_duration_seconds", "Seconds a button was held for.", m.durations)
+}
+
+// histogram accumulates Prometheus-style cumulative bucket counts, a running
+// sum, and a running count as samples are observed, so ServeHTTP can render a
+// scrape in O(len(histogramBuckets)) time regardless of how many samples a
+// long-running process has seen, rather than retaining every raw sample.
+type histogram struct {
+	bucketCounts []uint64 // bucketCounts[i] = count of samples <= histogramBuckets[i]
+	sum          float64
+	count        uint64
+}
+
+func newHistogram() *histogram {
+	return &histogram{bucketCounts: make([]uint64, len(histogramBuckets))}
+}
+
+func (h *histogram) observe(v float64) {
+	for i, bound := range histogramBuckets {
+		if v <= bound {
+			h.bucketCounts[i]++
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+// writeHistogram renders one Prometheus histogram, one series per pin.
+func writeHistogram(w io.Writer, name, help string, byPin map[string]*histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	for pin, h := range byPin {
+		for i, bound := range histogramBuckets {
+			fmt.Fprintf(w, "%s_bucket{pin=%q,le=\"%g\"} %d\n", name, pin, bound, h.bucketCounts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{pin=%q,le=\"+Inf\"} %d\n", name, pin, h.count)
+		fmt.Fprintf(w, "%s_sum{pin=%q} %g\n", name, pin, h.sum)
+		fmt.Fprintf(w, "%s_count{pin=%q} %d\n", name, pin, h.count)
+	}
+}
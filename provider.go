@@ -0,0 +1,76 @@
+package gpiobuttons
+
+import "time"
+
+// EdgeType identifies a raw GPIO edge, independent of any particular
+// backend's representation.
+type EdgeType int
+
+const (
+	RisingEdge EdgeType = iota
+	FallingEdge
+)
+
+// EdgeEvent is a single raw edge reported by a LineProvider.
+type EdgeEvent struct {
+	Type      EdgeType
+	Timestamp time.Time
+}
+
+// LineRequest describes how a button should request and monitor a GPIO line,
+// independent of the backend that will service it.
+type LineRequest struct {
+	Chip         string
+	Offset       int
+	Consumer     string
+	ActiveLow    bool
+	Pull         Pull
+	DebounceTime time.Duration // 0 disables kernel debounce negotiation
+	EdgeHandler  func(EdgeEvent)
+}
+
+// ProvidedLine is a handle to a requested line that can be released.
+type ProvidedLine interface {
+	Close() error
+}
+
+// LineGroupRequest describes a set of lines on one chip to request
+// atomically, so their states can be read together for chord detection.
+type LineGroupRequest struct {
+	Chip        string
+	Offsets     []int
+	Consumer    string
+	ActiveLow   bool
+	Pull        Pull
+	EdgeHandler func(offset int, evt EdgeEvent)
+}
+
+// ProvidedLineGroup is a handle to an atomically-requested set of lines.
+type ProvidedLineGroup interface {
+	Close() error
+}
+
+// GroupLineProvider is implemented by LineProviders that can request
+// multiple lines on one chip in a single atomic call (gpiocdev's
+// RequestLines). ButtonManager.AddGroup uses it when available, and falls
+// back to requesting each line individually otherwise.
+type GroupLineProvider interface {
+	RequestLines(req LineGroupRequest) (ProvidedLineGroup, error)
+}
+
+// LineProvider abstracts the GPIO backend used by ButtonManager. The default
+// is gpiocdevProvider (the Linux GPIO character device); sysfsProvider and
+// MockProvider are alternate implementations for kernels without
+// CONFIG_GPIO_CDEV and for hardware-free tests, respectively.
+type LineProvider interface {
+	// RequestLine claims a line for edge-triggered input and starts
+	// delivering EdgeEvents to req.EdgeHandler until the returned
+	// ProvidedLine is closed.
+	RequestLine(req LineRequest) (ProvidedLine, error)
+
+	// Chips lists the names of GPIO chips this provider can see.
+	Chips() []string
+
+	// LineInfo describes a single line of a chip.
+	LineInfo(chip string, offset int) (LineInfo, error)
+}
@@ -0,0 +1,175 @@
+package gpiobuttons
+
+import (
+	"fmt"
+	"time"
+
+	gpiocdev "github.com/warthog618/go-gpiocdev"
+)
+
+// gpiocdevProvider is the default LineProvider, backed by the Linux GPIO
+// character device (CONFIG_GPIO_CDEV) via warthog618/go-gpiocdev.
+type gpiocdevProvider struct{}
+
+// NewGpiocdevProvider returns the default character-device-backed
+// LineProvider used by NewButtonManager.
+func NewGpiocdevProvider() LineProvider {
+	return gpiocdevProvider{}
+}
+
+func (gpiocdevProvider) Chips() []string {
+	return gpiocdev.Chips()
+}
+
+func (gpiocdevProvider) LineInfo(chip string, offset int) (LineInfo, error) {
+	c, err := gpiocdev.NewChip(chip)
+	if err != nil {
+		return LineInfo{}, err
+	}
+	defer c.Close()
+
+	li, err := c.LineInfo(offset)
+	if err != nil {
+		return LineInfo{}, err
+	}
+	return LineInfo{Chip: chip, Offset: offset, Name: li.Name, Consumer: li.Consumer}, nil
+}
+
+// pullOption maps Pull to the matching gpiocdev request option.
+func pullOption(p Pull) gpiocdev.LineReqOption {
+	switch p {
+	case PullUp:
+		return gpiocdev.WithPullUp
+	case PullDown:
+		return gpiocdev.WithPullDown
+	case PullDisabled:
+		return gpiocdev.WithBiasDisabled
+	default:
+		return nil // WithBiasAsIs is default
+	}
+}
+
+// RequestLine requests a line via gpiocdev, retrying with progressively
+// looser option sets when the kernel/driver rejects debounce or bias
+// negotiation (full options, then without bias, then without debounce, then
+// the base options only).
+func (p gpiocdevProvider) RequestLine(req LineRequest) (ProvidedLine, error) {
+	handler := func(evt gpiocdev.LineEvent) {
+		if req.EdgeHandler == nil {
+			return
+		}
+		edgeType := RisingEdge
+		if evt.Type == gpiocdev.LineEventFallingEdge {
+			edgeType = FallingEdge
+		}
+		// evt.Timestamp is a monotonic duration since an unspecified epoch,
+		// not a wall clock reading, so it can't populate EdgeEvent.Timestamp
+		// directly; take the wall time as close to delivery as we can.
+		req.EdgeHandler(EdgeEvent{Type: edgeType, Timestamp: time.Now()})
+	}
+
+	base := []gpiocdev.LineReqOption{
+		gpiocdev.AsInput,
+		gpiocdev.WithConsumer(req.Consumer),
+		gpiocdev.WithBothEdges,
+	}
+	if req.ActiveLow {
+		base = append(base, gpiocdev.AsActiveLow)
+	}
+
+	type combo struct {
+		opts      []gpiocdev.LineReqOption
+		debounced bool
+	}
+	var combos []combo
+
+	full := append([]gpiocdev.LineReqOption{}, base...)
+	full = append(full, gpiocdev.WithEventHandler(handler))
+	if req.DebounceTime > 0 {
+		full = append(full, gpiocdev.WithDebounce(req.DebounceTime))
+	}
+	if pOpt := pullOption(req.Pull); pOpt != nil {
+		full = append(full, pOpt)
+	}
+	combos = append(combos, combo{full, req.DebounceTime > 0})
+
+	if pOpt := pullOption(req.Pull); pOpt != nil {
+		noBias := append([]gpiocdev.LineReqOption{}, base...)
+		noBias = append(noBias, gpiocdev.WithEventHandler(handler))
+		if req.DebounceTime > 0 {
+			noBias = append(noBias, gpiocdev.WithDebounce(req.DebounceTime))
+		}
+		combos = append(combos, combo{noBias, req.DebounceTime > 0})
+	}
+	if req.DebounceTime > 0 {
+		noDeb := append([]gpiocdev.LineReqOption{}, base...)
+		noDeb = append(noDeb, gpiocdev.WithEventHandler(handler))
+		if pOpt := pullOption(req.Pull); pOpt != nil {
+			noDeb = append(noDeb, pOpt)
+		}
+		combos = append(combos, combo{noDeb, false})
+	}
+	baseOnly := append([]gpiocdev.LineReqOption{}, base...)
+	baseOnly = append(baseOnly, gpiocdev.WithEventHandler(handler))
+	combos = append(combos, combo{baseOnly, false})
+
+	var line *gpiocdev.Line
+	var reqErr error
+	for _, c := range combos {
+		line, reqErr = gpiocdev.RequestLine(req.Chip, req.Offset, c.opts...)
+		if reqErr == nil {
+			return &gpiocdevLine{Line: line, kernelDebounced: c.debounced}, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to request line %s:%d: %w", req.Chip, req.Offset, reqErr)
+}
+
+// RequestLines requests multiple lines on one chip in a single gpiocdev call,
+// so their states are read atomically. This is what backs ButtonManager's
+// chord/combo support: with independent per-line requests, two lines'
+// "simultaneous" presses could race; a single RequestLines call cannot.
+func (p gpiocdevProvider) RequestLines(req LineGroupRequest) (ProvidedLineGroup, error) {
+	handler := func(evt gpiocdev.LineEvent) {
+		if req.EdgeHandler == nil {
+			return
+		}
+		edgeType := RisingEdge
+		if evt.Type == gpiocdev.LineEventFallingEdge {
+			edgeType = FallingEdge
+		}
+		// See the Timestamp comment in RequestLine's handler above: evt.Timestamp
+		// isn't wall-clock, so stamp the edge ourselves at delivery.
+		req.EdgeHandler(evt.Offset, EdgeEvent{Type: edgeType, Timestamp: time.Now()})
+	}
+
+	opts := []gpiocdev.LineReqOption{
+		gpiocdev.AsInput,
+		gpiocdev.WithConsumer(req.Consumer),
+		gpiocdev.WithBothEdges,
+		gpiocdev.WithEventHandler(handler),
+	}
+	if req.ActiveLow {
+		opts = append(opts, gpiocdev.AsActiveLow)
+	}
+	if pOpt := pullOption(req.Pull); pOpt != nil {
+		opts = append(opts, pOpt)
+	}
+
+	lines, err := gpiocdev.RequestLines(req.Chip, req.Offsets, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request lines %s%v: %w", req.Chip, req.Offsets, err)
+	}
+	return lines, nil
+}
+
+// gpiocdevLine wraps a requested line with whether the kernel actually
+// accepted debounce negotiation, so AddButton can decide whether the
+// software integrator is needed in DebounceMode Auto.
+type gpiocdevLine struct {
+	*gpiocdev.Line
+	kernelDebounced bool
+}
+
+// KernelDebounced reports whether this line's request included a debounce
+// option the kernel/driver accepted.
+func (l *gpiocdevLine) KernelDebounced() bool { return l.kernelDebounced }
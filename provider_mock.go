@@ -0,0 +1,76 @@
+package gpiobuttons
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MockProvider is a LineProvider that requires no hardware. Tests use
+// Inject to deliver synthetic edge events to whichever button last
+// requested a given chip:offset.
+type MockProvider struct {
+	ChipNames []string // Chips() return value; defaults to []string{"mock0"} if empty
+
+	mu    sync.Mutex
+	lines map[string]*mockLine
+}
+
+// NewMockProvider returns a MockProvider with no hardware dependency,
+// suitable for unit-testing ButtonManager callbacks.
+func NewMockProvider() *MockProvider {
+	return &MockProvider{lines: make(map[string]*mockLine)}
+}
+
+func (m *MockProvider) Chips() []string {
+	if len(m.ChipNames) > 0 {
+		return m.ChipNames
+	}
+	return []string{"mock0"}
+}
+
+func (m *MockProvider) LineInfo(chip string, offset int) (LineInfo, error) {
+	return LineInfo{Chip: chip, Offset: offset, Name: fmt.Sprintf("%s:%d", chip, offset)}, nil
+}
+
+func (m *MockProvider) RequestLine(req LineRequest) (ProvidedLine, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := lineKey(req.Chip, req.Offset)
+	if _, exists := m.lines[key]; exists {
+		return nil, fmt.Errorf("mock line %s already requested", key)
+	}
+	line := &mockLine{provider: m, key: key, handler: req.EdgeHandler}
+	m.lines[key] = line
+	return line, nil
+}
+
+// Inject delivers a synthetic edge event to the line previously requested on
+// chip:offset, as if it had come from real hardware. It is a no-op if no
+// button currently holds that line.
+func (m *MockProvider) Inject(chip string, offset int, evt EdgeEvent) {
+	m.mu.Lock()
+	line, ok := m.lines[lineKey(chip, offset)]
+	m.mu.Unlock()
+	if !ok || line.handler == nil {
+		return
+	}
+	line.handler(evt)
+}
+
+func lineKey(chip string, offset int) string {
+	return fmt.Sprintf("%s:%d", chip, offset)
+}
+
+type mockLine struct {
+	provider *MockProvider
+	key      string
+	handler  func(EdgeEvent)
+}
+
+func (l *mockLine) Close() error {
+	l.provider.mu.Lock()
+	delete(l.provider.lines, l.key)
+	l.provider.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,155 @@
+package gpiobuttons
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const sysfsGPIOPath = "/sys/class/gpio"
+
+// sysfsProvider is a LineProvider for kernels without CONFIG_GPIO_CDEV. It
+// drives the legacy /sys/class/gpio interface and watches each line's
+// "value" file with epoll(EPOLLPRI), in the spirit of Dave Cheney's
+// EPOLL-based interrupt code that embd later imported.
+type sysfsProvider struct {
+	chip string // sysfs has no chip concept; this is a label only
+}
+
+// NewSysfsProvider returns a LineProvider backed by the legacy sysfs GPIO
+// interface. chip is a display label only: sysfs exposes one flat numbering
+// space, so every line is reported under it.
+func NewSysfsProvider(chip string) LineProvider {
+	return &sysfsProvider{chip: chip}
+}
+
+func (p *sysfsProvider) Chips() []string { return []string{p.chip} }
+
+func (p *sysfsProvider) LineInfo(chip string, offset int) (LineInfo, error) {
+	return LineInfo{Chip: chip, Offset: offset, Name: fmt.Sprintf("gpio%d", offset)}, nil
+}
+
+// RequestLine exports the line, configures it as a both-edges input, and
+// starts a watcher goroutine that epolls the value file for interrupts.
+func (p *sysfsProvider) RequestLine(req LineRequest) (ProvidedLine, error) {
+	if err := sysfsExport(req.Offset); err != nil {
+		return nil, fmt.Errorf("export gpio%d: %w", req.Offset, err)
+	}
+	dir := filepath.Join(sysfsGPIOPath, fmt.Sprintf("gpio%d", req.Offset))
+
+	if err := sysfsWrite(filepath.Join(dir, "direction"), "in"); err != nil {
+		_ = sysfsUnexport(req.Offset)
+		return nil, fmt.Errorf("set gpio%d direction: %w", req.Offset, err)
+	}
+	if err := sysfsWrite(filepath.Join(dir, "edge"), "both"); err != nil {
+		_ = sysfsUnexport(req.Offset)
+		return nil, fmt.Errorf("gpio%d does not support edge interrupts via sysfs: %w", req.Offset, err)
+	}
+	if req.ActiveLow {
+		_ = sysfsWrite(filepath.Join(dir, "active_low"), "1")
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, "value"), os.O_RDONLY, 0)
+	if err != nil {
+		_ = sysfsUnexport(req.Offset)
+		return nil, err
+	}
+
+	epfd, err := unix.EpollCreate1(0)
+	if err != nil {
+		f.Close()
+		_ = sysfsUnexport(req.Offset)
+		return nil, err
+	}
+	fd := int(f.Fd())
+	if err := unix.EpollCtl(epfd, unix.EPOLL_CTL_ADD, fd, &unix.EpollEvent{Events: unix.EPOLLPRI | unix.EPOLLERR, Fd: int32(fd)}); err != nil {
+		unix.Close(epfd)
+		f.Close()
+		_ = sysfsUnexport(req.Offset)
+		return nil, err
+	}
+
+	line := &sysfsLine{offset: req.Offset, file: f, epfd: epfd, stop: make(chan struct{})}
+	// The first epoll wait returns immediately with the line's current value;
+	// read and discard it so only genuine transitions reach the handler.
+	line.readValue()
+	go line.watch(req.EdgeHandler)
+	return line, nil
+}
+
+// sysfsLine is the ProvidedLine handle returned by sysfsProvider.
+type sysfsLine struct {
+	offset int
+	file   *os.File
+	epfd   int
+	stop   chan struct{}
+}
+
+func (l *sysfsLine) watch(handler func(EdgeEvent)) {
+	events := make([]unix.EpollEvent, 1)
+	var last byte
+	for {
+		select {
+		case <-l.stop:
+			return
+		default:
+		}
+		n, err := unix.EpollWait(l.epfd, events, 250)
+		if err != nil || n == 0 {
+			continue
+		}
+		v, err := l.readValue()
+		if err != nil || v == last {
+			continue
+		}
+		last = v
+		edge := FallingEdge
+		if v == '1' {
+			edge = RisingEdge
+		}
+		if handler != nil {
+			handler(EdgeEvent{Type: edge, Timestamp: time.Now()})
+		}
+	}
+}
+
+func (l *sysfsLine) readValue() (byte, error) {
+	if _, err := l.file.Seek(0, 0); err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 1)
+	if _, err := l.file.Read(buf); err != nil {
+		return 0, err
+	}
+	return buf[0], nil
+}
+
+func (l *sysfsLine) Close() error {
+	close(l.stop)
+	unix.Close(l.epfd)
+	err := l.file.Close()
+	_ = sysfsUnexport(l.offset)
+	return err
+}
+
+func sysfsExport(offset int) error {
+	return sysfsWrite(filepath.Join(sysfsGPIOPath, "export"), strconv.Itoa(offset))
+}
+
+func sysfsUnexport(offset int) error {
+	return sysfsWrite(filepath.Join(sysfsGPIOPath, "unexport"), strconv.Itoa(offset))
+}
+
+func sysfsWrite(path, value string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(value)
+	return err
+}
@@ -0,0 +1,114 @@
+// Package pwm drives Linux PWM outputs through the sysfs PWM interface
+// (/sys/class/pwm/pwmchipN/pwmM), in the spirit of embd's BeagleBone Black
+// PWM support. It has no dependency on gpiobuttons and can be used
+// standalone, but gpiobuttons.ButtonConfig.Feedback builds on it to give LED
+// acknowledgement of button presses without extra plumbing.
+package pwm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const sysfsPWMPath = "/sys/class/pwm"
+
+// PWMOutput is a single exported PWM channel.
+type PWMOutput struct {
+	chip    int
+	channel int
+	dir     string
+
+	mu      sync.Mutex
+	period  time.Duration
+	running bool
+}
+
+// NewPWMOutput exports pwmchip<chip>/pwm<channel> if it isn't already, and
+// returns a handle to configure and drive it.
+func NewPWMOutput(chip, channel int) (*PWMOutput, error) {
+	chipDir := filepath.Join(sysfsPWMPath, fmt.Sprintf("pwmchip%d", chip))
+	dir := filepath.Join(chipDir, fmt.Sprintf("pwm%d", channel))
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := sysfsWrite(filepath.Join(chipDir, "export"), strconv.Itoa(channel)); err != nil {
+			return nil, fmt.Errorf("export pwmchip%d/pwm%d: %w", chip, channel, err)
+		}
+	}
+	return &PWMOutput{chip: chip, channel: channel, dir: dir}, nil
+}
+
+// SetPeriod sets the PWM period.
+func (p *PWMOutput) SetPeriod(d time.Duration) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.writeNanoseconds("period", d); err != nil {
+		return err
+	}
+	p.period = d
+	return nil
+}
+
+// Period returns the period last set with SetPeriod.
+func (p *PWMOutput) Period() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.period
+}
+
+// SetDutyCycle sets the high time within each period. It must be <= the
+// currently configured Period.
+func (p *PWMOutput) SetDutyCycle(d time.Duration) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if d > p.period {
+		return fmt.Errorf("duty cycle %s exceeds period %s", d, p.period)
+	}
+	return p.writeNanoseconds("duty_cycle", d)
+}
+
+// Start enables PWM output.
+func (p *PWMOutput) Start() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := sysfsWrite(filepath.Join(p.dir, "enable"), "1"); err != nil {
+		return err
+	}
+	p.running = true
+	return nil
+}
+
+// Stop disables PWM output, leaving the channel exported.
+func (p *PWMOutput) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := sysfsWrite(filepath.Join(p.dir, "enable"), "0"); err != nil {
+		return err
+	}
+	p.running = false
+	return nil
+}
+
+// Close stops output and unexports the channel.
+func (p *PWMOutput) Close() error {
+	_ = p.Stop()
+	chipDir := filepath.Join(sysfsPWMPath, fmt.Sprintf("pwmchip%d", p.chip))
+	return sysfsWrite(filepath.Join(chipDir, "unexport"), strconv.Itoa(p.channel))
+}
+
+func (p *PWMOutput) writeNanoseconds(attr string, d time.Duration) error {
+	return sysfsWrite(filepath.Join(p.dir, attr), strconv.FormatInt(d.Nanoseconds(), 10))
+}
+
+func sysfsWrite(path, value string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(value)
+	return err
+}
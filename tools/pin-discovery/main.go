@@ -2,19 +2,18 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"sort"
 
-	gpiocdev "github.com/warthog618/go-gpiocdev"
+	gpiobuttons "github.com/piotrpaczula/gpio-buttons-go"
 )
 
 func main() {
 	fmt.Println("Discovering available GPIO chips/lines...")
 	fmt.Println("=====================================")
 
-	chipNames := gpiocdev.Chips()
-	if len(chipNames) == 0 {
-		fmt.Println("❌ No gpiochips found!")
+	lines, err := gpiobuttons.DiscoverLines()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
 		fmt.Println("\nTroubleshooting:")
 		fmt.Println("1. Run as root (sudo)")
 		fmt.Println("2. Kernel must have GPIO chardev (CONFIG_GPIO_CDEV)")
@@ -22,59 +21,30 @@ func main() {
 		return
 	}
 
-	// Flatten lines into a list of (chip, offset, name)
-	type lineInfo struct {
-		chip   string
-		offset int
-		name   string
-	}
-	var lines []lineInfo
-	for _, cname := range chipNames {
-		c, err := gpiocdev.NewChip(cname)
-		if err != nil {
-			log.Printf("Skipping %s: %v", cname, err)
-			continue
-		}
-		n := c.Lines()
-		for off := 0; off < n; off++ {
-			li, err := c.LineInfo(off)
-			if err != nil {
-				continue
-			}
-			lines = append(lines, lineInfo{chip: cname, offset: off, name: li.Name})
-		}
-		_ = c.Close()
-	}
 	sort.Slice(lines, func(i, j int) bool {
-		if lines[i].chip == lines[j].chip {
-			return lines[i].offset < lines[j].offset
+		if lines[i].Chip == lines[j].Chip {
+			return lines[i].Offset < lines[j].Offset
 		}
-		return lines[i].chip < lines[j].chip
+		return lines[i].Chip < lines[j].Chip
 	})
 
 	fmt.Println("Chip:Offset               | Bias Pull-Up Support | Name")
 	fmt.Println("--------------------------|----------------------|------")
 
 	supported := 0
-	checked := 0
 	for _, ln := range lines {
-		checked++
-		// Try to request the line as input with bias pull-up.
-		// Many lines may be busy or not support bias; ignore busy errors gracefully.
 		pullSupport := "❌ NO"
-		l, err := gpiocdev.RequestLine(ln.chip, ln.offset, gpiocdev.AsInput, gpiocdev.WithPullUp, gpiocdev.WithConsumer("pin-discovery"))
-		if err == nil {
+		if ln.SupportsPullUp {
 			pullSupport = "✅ YES"
 			supported++
-			_ = l.Close()
 		}
 
-		label := fmt.Sprintf("%s:%d", ln.chip, ln.offset)
-		fmt.Printf("%-26s | %-20s | %s\n", label, pullSupport, ln.name)
+		label := fmt.Sprintf("%s:%d", ln.Chip, ln.Offset)
+		fmt.Printf("%-26s | %-20s | %s\n", label, pullSupport, ln.Name)
 	}
 
 	fmt.Println("\n=====================================")
-	fmt.Printf("✅ Lines with pull-up support: %d (out of %d checked)\n\n", supported, checked)
+	fmt.Printf("✅ Lines with pull-up support: %d (out of %d checked)\n\n", supported, len(lines))
 
 	if supported == 0 {
 		fmt.Println("⚠️  No lines accepted bias=pull-up via userspace request.")
@@ -82,6 +52,6 @@ func main() {
 		fmt.Println("Options: use external pull resistors, or set bias in device tree overlays.")
 	} else {
 		fmt.Println("Example usage with this library:")
-		fmt.Println("  PinName: \"gpiochip0:23\"  // chip:line format")
+		fmt.Println("  PinName: \"gpiochip0:23\"  // chip:line format, or a symbolic line name like \"BUTTON_A\"")
 	}
 }